@@ -0,0 +1,139 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 支持的认证机制
+const (
+	AuthMechanismSCRAMSHA1   = "SCRAM-SHA-1"
+	AuthMechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	AuthMechanismX509        = "MONGODB-X509"
+	AuthMechanismPLAIN       = "PLAIN" // LDAP 代理认证
+	AuthMechanismGSSAPI      = "GSSAPI"
+	AuthMechanismAWS         = "MONGODB-AWS"
+	AuthMechanismOIDC        = "MONGODB-OIDC"
+)
+
+// externalAuthSource 外部认证机制使用的认证数据库
+const externalAuthSource = "$external"
+
+// TokenProvider 为 MONGODB-OIDC 认证提供访问令牌（例如工作负载身份令牌）
+type TokenProvider interface {
+	// Token 返回访问令牌及其过期时间，过期时间为零值表示由服务端决定
+	Token(ctx context.Context) (token string, expiresAt time.Time, err error)
+}
+
+// TokenProviderFunc 函数形式的 TokenProvider
+type TokenProviderFunc func(ctx context.Context) (string, time.Time, error)
+
+// Token 实现 TokenProvider 接口
+func (f TokenProviderFunc) Token(ctx context.Context) (string, time.Time, error) {
+	return f(ctx)
+}
+
+// oidcCallback 将 TokenProvider 适配为驱动的 OIDC 回调
+func oidcCallback(provider TokenProvider) options.OIDCCallback {
+	return func(ctx context.Context, _ *options.OIDCArgs) (*options.OIDCCredential, error) {
+		token, expiresAt, err := provider.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get mongodb oidc token: %w", err)
+		}
+		cred := &options.OIDCCredential{AccessToken: token}
+		if !expiresAt.IsZero() {
+			cred.ExpiresAt = &expiresAt
+		}
+		return cred, nil
+	}
+}
+
+// buildCredential 根据选项构建驱动认证凭据，不需要认证时返回 nil
+// 凭据不再拼接进连接串，避免密码出现在 URI 和日志中
+func buildCredential(opts *Options) (*options.Credential, error) {
+	cred := &options.Credential{
+		AuthMechanism:           opts.AuthMechanism,
+		AuthMechanismProperties: opts.AuthProperties,
+		Username:                opts.Username,
+		Password:                opts.Password,
+		PasswordSet:             opts.Password != "",
+	}
+
+	switch opts.AuthMechanism {
+	case "":
+		// 未指定机制时由驱动协商，与之前一样只在用户名和密码都提供时启用认证
+		if opts.Username == "" || opts.Password == "" {
+			return nil, nil
+		}
+		cred.AuthSource = opts.AuthSource
+	case AuthMechanismSCRAMSHA1, AuthMechanismSCRAMSHA256:
+		if opts.Username == "" || opts.Password == "" {
+			return nil, fmt.Errorf("mongodb auth_mechanism %s requires username and password", opts.AuthMechanism)
+		}
+		cred.AuthSource = opts.AuthSource
+	case AuthMechanismPLAIN:
+		if opts.Username == "" || opts.Password == "" {
+			return nil, fmt.Errorf("mongodb auth_mechanism %s requires username and password", opts.AuthMechanism)
+		}
+		cred.AuthSource = externalAuthSource
+	case AuthMechanismX509:
+		// 使用客户端证书的 subject 作为用户名，不需要密码
+		if opts.Password != "" {
+			return nil, fmt.Errorf("mongodb auth_mechanism %s does not use a password", opts.AuthMechanism)
+		}
+		cred.AuthSource = externalAuthSource
+	case AuthMechanismGSSAPI, AuthMechanismAWS:
+		cred.AuthSource = externalAuthSource
+	case AuthMechanismOIDC:
+		if opts.Password != "" {
+			return nil, fmt.Errorf("mongodb auth_mechanism %s does not use a password", opts.AuthMechanism)
+		}
+		if opts.TokenProvider == nil && opts.AuthProperties["ENVIRONMENT"] == "" {
+			return nil, fmt.Errorf("mongodb auth_mechanism %s requires a TokenProvider or ENVIRONMENT property", opts.AuthMechanism)
+		}
+		if opts.TokenProvider != nil {
+			cred.OIDCMachineCallback = oidcCallback(opts.TokenProvider)
+		}
+		cred.AuthSource = externalAuthSource
+	default:
+		return nil, fmt.Errorf("mongodb auth_mechanism %q is not supported", opts.AuthMechanism)
+	}
+	return cred, nil
+}
+
+// parseAuthMechanismProperties 解析 "KEY:VALUE,KEY2:VALUE2" 形式的认证机制属性（与连接串格式一致）
+func parseAuthMechanismProperties(s string) (map[string]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	props := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(pair, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid auth mechanism property %q, want KEY:VALUE", pair)
+		}
+		props[key] = strings.TrimSpace(value)
+	}
+	return props, nil
+}
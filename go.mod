@@ -6,6 +6,7 @@ require (
 	github.com/go-anyway/framework-config v1.0.0
 	github.com/go-anyway/framework-log v1.0.0
 	github.com/go-anyway/framework-trace v1.0.0
+	github.com/youmark/pkcs8 v0.0.0-20240726163527-a2c0da244d78
 	go.mongodb.org/mongo-driver v1.17.6
 )
 
@@ -19,13 +19,20 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// defaultConnectTimeout 连接串和选项均未指定连接超时时使用的默认值
+const defaultConnectTimeout = 10 * time.Second
+
 // MongoDBClient MongoDB 客户端封装
 type MongoDBClient struct {
 	Client   *mongo.Client
@@ -47,16 +54,11 @@ func newMongoDB(opts *Options) (*MongoDBClient, error) {
 		return nil, fmt.Errorf("mongodb options cannot be nil")
 	}
 
-	// 构建连接 URI
-	uri := buildURI(opts)
-
 	// 创建客户端选项
-	clientOptions := options.Client().
-		ApplyURI(uri).
-		SetMaxPoolSize(opts.MaxPoolSize).
-		SetMinPoolSize(opts.MinPoolSize).
-		SetConnectTimeout(opts.ConnectTimeout).
-		SetSocketTimeout(opts.SocketTimeout)
+	clientOptions, dbName, err := buildClientOptions(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// 如果启用了追踪，添加 CommandMonitor
 	if opts.EnableTrace {
@@ -69,7 +71,7 @@ func newMongoDB(opts *Options) (*MongoDBClient, error) {
 	}
 
 	// 连接 MongoDB（使用推荐的 mongo.Connect 方式）
-	ctx, cancel := context.WithTimeout(context.Background(), opts.ConnectTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), *clientOptions.ConnectTimeout)
 	defer cancel()
 
 	client, err := mongo.Connect(ctx, clientOptions)
@@ -83,7 +85,7 @@ func newMongoDB(opts *Options) (*MongoDBClient, error) {
 	}
 
 	// 获取数据库
-	database := client.Database(opts.Database)
+	database := client.Database(dbName)
 
 	return &MongoDBClient{
 		Client:   client,
@@ -92,24 +94,63 @@ func newMongoDB(opts *Options) (*MongoDBClient, error) {
 	}, nil
 }
 
-// buildURI 构建 MongoDB 连接 URI
-func buildURI(opts *Options) string {
-	// 构建基础 URI
-	var uri string
-	if opts.Username != "" && opts.Password != "" {
-		// 有认证信息，对用户名和密码进行 URL 编码，防止特殊字符导致连接失败
-		username := url.QueryEscape(opts.Username)
-		password := url.QueryEscape(opts.Password)
-		uri = fmt.Sprintf("mongodb://%s:%s@%s:%d", username, password, opts.Host, opts.Port)
+// buildClientOptions 根据选项构建驱动客户端选项，并返回要使用的数据库名称
+func buildClientOptions(opts *Options) (*options.ClientOptions, string, error) {
+	var clientOptions *options.ClientOptions
+	dbName := opts.Database
+	if opts.URI == "" {
+		clientOptions = options.Client().
+			ApplyURI(buildURI(opts)).
+			SetMaxPoolSize(opts.MaxPoolSize).
+			SetMinPoolSize(opts.MinPoolSize).
+			SetConnectTimeout(opts.ConnectTimeout).
+			SetSocketTimeout(opts.SocketTimeout)
 	} else {
-		// 无认证信息
-		uri = fmt.Sprintf("mongodb://%s:%d", opts.Host, opts.Port)
+		var err error
+		clientOptions, dbName, err = uriClientOptions(opts)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// TLS 配置
+	if opts.TLSEnabled {
+		tlsConfig, err := newTLSConfig(opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to build mongodb tls config: %w", err)
+		}
+		clientOptions.SetTLSConfig(tlsConfig)
 	}
 
+	// 认证凭据
+	if opts.URI == "" {
+		cred, err := buildCredential(opts)
+		if err != nil {
+			return nil, "", err
+		}
+		if cred != nil {
+			clientOptions.SetAuth(*cred)
+		}
+	}
+
+	return clientOptions, dbName, nil
+}
+
+// buildURI 构建 MongoDB 连接 URI
+// 认证信息通过 options.Credential 单独设置，不会拼接进 URI
+func buildURI(opts *Options) string {
+	uri := fmt.Sprintf("mongodb://%s", strings.Join(seedList(opts), ","))
+
 	// 添加查询参数
 	query := url.Values{}
-	if opts.AuthSource != "" {
-		query.Set("authSource", opts.AuthSource)
+	if opts.ReplicaSet != "" {
+		query.Set("replicaSet", opts.ReplicaSet)
+	}
+	if opts.Direct {
+		query.Set("directConnection", "true")
+	}
+	if opts.LoadBalanced {
+		query.Set("loadBalanced", "true")
 	}
 	if len(query) > 0 {
 		uri += "?" + query.Encode()
@@ -118,6 +159,53 @@ func buildURI(opts *Options) string {
 	return uri
 }
 
+// seedList 返回连接使用的种子主机列表（host:port 形式）
+// 未配置 Hosts 时退化为单个 Host:Port；Hosts 中未写端口的条目使用 Port 作为默认端口
+func seedList(opts *Options) []string {
+	if len(opts.Hosts) == 0 {
+		return []string{net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))}
+	}
+	seeds := make([]string, 0, len(opts.Hosts))
+	for _, h := range opts.Hosts {
+		host, port, err := splitHostPort(h, opts.Port)
+		if err != nil {
+			// 校验在 Config.Validate 中完成，这里原样保留交给驱动报错
+			seeds = append(seeds, strings.TrimSpace(h))
+			continue
+		}
+		seeds = append(seeds, net.JoinHostPort(host, strconv.Itoa(port)))
+	}
+	return seeds
+}
+
+// splitHostPort 解析 host 或 host:port（IPv6 需使用 [addr]:port 形式），缺省端口时使用 defaultPort
+func splitHostPort(hostport string, defaultPort int) (string, int, error) {
+	hostport = strings.TrimSpace(hostport)
+	if hostport == "" {
+		return "", 0, fmt.Errorf("host cannot be empty")
+	}
+
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		// 未写端口：主机名、未加方括号的 IPv6 地址或 [IPv6]
+		if strings.HasPrefix(hostport, "[") {
+			if !strings.HasSuffix(hostport, "]") {
+				return "", 0, fmt.Errorf("invalid host %q: %w", hostport, err)
+			}
+			return hostport[1 : len(hostport)-1], defaultPort, nil
+		}
+		return hostport, defaultPort, nil
+	}
+	if host == "" {
+		return "", 0, fmt.Errorf("invalid host %q: host cannot be empty", hostport)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port in %q: must be between 1 and 65535", hostport)
+	}
+	return host, port, nil
+}
+
 // Close 关闭 MongoDB 连接
 func (c *MongoDBClient) Close(ctx context.Context) error {
 	if c.Client == nil {
@@ -26,12 +26,26 @@ import (
 // Config MongoDB 配置结构体（用于从配置文件创建）
 type Config struct {
 	Enabled        bool               `yaml:"enabled" env:"MONGODB_ENABLED" default:"true"`
+	URI            string             `yaml:"uri" env:"MONGODB_URI"`
 	Host           string             `yaml:"host" env:"MONGODB_HOST" default:"localhost"`
 	Port           int                `yaml:"port" env:"MONGODB_PORT" default:"27017"`
-	Database       string             `yaml:"database" env:"MONGODB_DATABASE" required:"true"`
+	Hosts          []string           `yaml:"hosts" env:"MONGODB_HOSTS"`
+	ReplicaSet     string             `yaml:"replica_set" env:"MONGODB_REPLICA_SET"`
+	Direct         bool               `yaml:"direct_connection" env:"MONGODB_DIRECT_CONNECTION" default:"false"`
+	LoadBalanced   bool               `yaml:"load_balanced" env:"MONGODB_LOAD_BALANCED" default:"false"`
+	Database       string             `yaml:"database" env:"MONGODB_DATABASE"`
 	Username       string             `yaml:"username" env:"MONGODB_USERNAME"`
 	Password       string             `yaml:"password" env:"MONGODB_PASSWORD"`
 	AuthSource     string             `yaml:"auth_source" env:"MONGODB_AUTH_SOURCE" default:"admin"`
+	AuthMechanism  string             `yaml:"auth_mechanism" env:"MONGODB_AUTH_MECHANISM"`
+	AuthProperties string             `yaml:"auth_mechanism_properties" env:"MONGODB_AUTH_MECHANISM_PROPERTIES"`
+	TLSEnabled     bool               `yaml:"tls_enabled" env:"MONGODB_TLS_ENABLED" default:"false"`
+	TLSCAFile      string             `yaml:"tls_ca_file" env:"MONGODB_TLS_CA_FILE"`
+	TLSCertFile    string             `yaml:"tls_cert_file" env:"MONGODB_TLS_CERT_FILE"`
+	TLSKeyFile     string             `yaml:"tls_key_file" env:"MONGODB_TLS_KEY_FILE"`
+	TLSKeyPassword string             `yaml:"tls_key_password" env:"MONGODB_TLS_KEY_PASSWORD"`
+	TLSServerName  string             `yaml:"tls_server_name" env:"MONGODB_TLS_SERVER_NAME"`
+	TLSInsecure    bool               `yaml:"tls_insecure_skip_verify" env:"MONGODB_TLS_INSECURE_SKIP_VERIFY" default:"false"`
 	MaxPoolSize    int                `yaml:"max_pool_size" env:"MONGODB_MAX_POOL_SIZE" default:"100"`
 	MinPoolSize    int                `yaml:"min_pool_size" env:"MONGODB_MIN_POOL_SIZE" default:"10"`
 	ConnectTimeout pkgConfig.Duration `yaml:"connect_timeout" env:"MONGODB_CONNECT_TIMEOUT" default:"10s"`
@@ -47,8 +61,23 @@ func (c *Config) Validate() error {
 	if !c.Enabled {
 		return nil // 如果未启用，不需要验证
 	}
-	if c.Database == "" {
-		return fmt.Errorf("mongodb database is required")
+	if c.URI != "" {
+		if err := c.validateURI(); err != nil {
+			return err
+		}
+	} else {
+		if c.Database == "" {
+			return fmt.Errorf("mongodb database is required")
+		}
+		if err := c.validateTopology(); err != nil {
+			return err
+		}
+	}
+	if err := c.validateTLS(); err != nil {
+		return err
+	}
+	if err := c.validateAuth(); err != nil {
+		return err
 	}
 	if c.Port < 1 || c.Port > 65535 {
 		return fmt.Errorf("mongodb port must be between 1 and 65535, got %d", c.Port)
@@ -65,6 +94,107 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// validateTopology 验证主机列表与拓扑相关配置
+func (c *Config) validateTopology() error {
+	for i, h := range c.Hosts {
+		if _, _, err := splitHostPort(h, c.Port); err != nil {
+			return fmt.Errorf("mongodb hosts[%d] is invalid: %w", i, err)
+		}
+	}
+	if len(c.Hosts) == 0 && c.Host == "" {
+		return fmt.Errorf("mongodb host or hosts is required")
+	}
+	seeds := len(c.Hosts)
+	if seeds == 0 {
+		seeds = 1
+	}
+	if c.Direct && seeds > 1 {
+		return fmt.Errorf("mongodb direct_connection requires exactly one host, got %d", seeds)
+	}
+	if c.LoadBalanced {
+		if seeds > 1 {
+			return fmt.Errorf("mongodb load_balanced requires exactly one host, got %d", seeds)
+		}
+		if c.ReplicaSet != "" {
+			return fmt.Errorf("mongodb load_balanced cannot be used with replica_set")
+		}
+		if c.Direct {
+			return fmt.Errorf("mongodb load_balanced cannot be used with direct_connection")
+		}
+	}
+	return nil
+}
+
+// validateTLS 验证 TLS 与 x.509 认证配置
+func (c *Config) validateTLS() error {
+	if !c.TLSEnabled {
+		if c.TLSCAFile != "" || c.TLSCertFile != "" || c.TLSKeyFile != "" || c.TLSServerName != "" || c.TLSInsecure {
+			return fmt.Errorf("mongodb tls_* options require tls_enabled")
+		}
+	}
+	if c.TLSKeyFile != "" && c.TLSCertFile == "" {
+		return fmt.Errorf("mongodb tls_key_file requires tls_cert_file")
+	}
+	if c.TLSKeyPassword != "" && c.TLSCertFile == "" {
+		return fmt.Errorf("mongodb tls_key_password requires tls_cert_file")
+	}
+	if c.TLSInsecure && c.TLSCAFile != "" {
+		return fmt.Errorf("mongodb tls_insecure_skip_verify cannot be used with tls_ca_file")
+	}
+	return nil
+}
+
+// validateAuth 验证认证机制配置
+// OIDC 的 TokenProvider 只能通过 Options 以代码方式注入，因此这里不校验其是否存在
+func (c *Config) validateAuth() error {
+	if _, err := parseAuthMechanismProperties(c.AuthProperties); err != nil {
+		return fmt.Errorf("mongodb auth_mechanism_properties is invalid: %w", err)
+	}
+	switch c.AuthMechanism {
+	case "", AuthMechanismGSSAPI, AuthMechanismAWS:
+	case AuthMechanismSCRAMSHA1, AuthMechanismSCRAMSHA256, AuthMechanismPLAIN:
+		// URI 模式下用户名和密码可能来自连接串
+		if c.URI == "" && (c.Username == "" || c.Password == "") {
+			return fmt.Errorf("mongodb auth_mechanism %s requires username and password", c.AuthMechanism)
+		}
+	case AuthMechanismX509:
+		if !c.TLSEnabled || c.TLSCertFile == "" {
+			return fmt.Errorf("mongodb auth_mechanism %s requires tls_enabled and tls_cert_file", AuthMechanismX509)
+		}
+		if c.Password != "" {
+			return fmt.Errorf("mongodb auth_mechanism %s does not use a password", AuthMechanismX509)
+		}
+	case AuthMechanismOIDC:
+		if c.Password != "" {
+			return fmt.Errorf("mongodb auth_mechanism %s does not use a password", AuthMechanismOIDC)
+		}
+	default:
+		return fmt.Errorf("mongodb auth_mechanism %q is not supported", c.AuthMechanism)
+	}
+	return nil
+}
+
+// validateURI 验证原始连接串及其与结构化字段的冲突
+func (c *Config) validateURI() error {
+	o := &Options{
+		URI:          c.URI,
+		Hosts:        c.Hosts,
+		ReplicaSet:   c.ReplicaSet,
+		Direct:       c.Direct,
+		LoadBalanced: c.LoadBalanced,
+		Username:     c.Username,
+		Password:     c.Password,
+	}
+	cs, err := o.parseURI()
+	if err != nil {
+		return err
+	}
+	if c.Database == "" && cs.Database == "" {
+		return fmt.Errorf("mongodb database is required (set database or include it in uri path)")
+	}
+	return nil
+}
+
 // ToOptions 转换为 Options
 func (c *Config) ToOptions() (*Options, error) {
 	if err := c.Validate(); err != nil {
@@ -74,9 +204,14 @@ func (c *Config) ToOptions() (*Options, error) {
 		return nil, fmt.Errorf("mongodb is not enabled")
 	}
 
+	authProperties, err := parseAuthMechanismProperties(c.AuthProperties)
+	if err != nil {
+		return nil, err
+	}
+
 	connectTimeout := c.ConnectTimeout.Duration()
 	if connectTimeout == 0 {
-		connectTimeout = 10 * time.Second
+		connectTimeout = defaultConnectTimeout
 	}
 	socketTimeout := c.SocketTimeout.Duration()
 	if socketTimeout == 0 {
@@ -84,12 +219,26 @@ func (c *Config) ToOptions() (*Options, error) {
 	}
 
 	return &Options{
+		URI:            c.URI,
 		Host:           c.Host,
 		Port:           c.Port,
+		Hosts:          c.Hosts,
+		ReplicaSet:     c.ReplicaSet,
+		Direct:         c.Direct,
+		LoadBalanced:   c.LoadBalanced,
 		Database:       c.Database,
 		Username:       c.Username,
 		Password:       c.Password,
 		AuthSource:     c.AuthSource,
+		AuthMechanism:  c.AuthMechanism,
+		AuthProperties: authProperties,
+		TLSEnabled:     c.TLSEnabled,
+		TLSCAFile:      c.TLSCAFile,
+		TLSCertFile:    c.TLSCertFile,
+		TLSKeyFile:     c.TLSKeyFile,
+		TLSKeyPassword: c.TLSKeyPassword,
+		TLSServerName:  c.TLSServerName,
+		TLSInsecure:    c.TLSInsecure,
 		MaxPoolSize:    uint64(c.MaxPoolSize),
 		MinPoolSize:    uint64(c.MinPoolSize),
 		ConnectTimeout: connectTimeout,
@@ -110,12 +259,27 @@ func (c *Config) SocketTimeoutDuration() time.Duration {
 
 // Options 结构体定义了 MongoDB 连接器的配置选项（内部使用）
 type Options struct {
+	URI            string // 原始连接串（mongodb:// 或 mongodb+srv://），与结构化字段合并使用
 	Host           string
 	Port           int
+	Hosts          []string // 多主机种子列表，非空时优先于 Host/Port
+	ReplicaSet     string   // 副本集名称
+	Direct         bool     // 是否直连单个节点（不进行拓扑发现）
+	LoadBalanced   bool     // 是否通过负载均衡器连接
 	Database       string
 	Username       string
 	Password       string
 	AuthSource     string
+	AuthMechanism  string            // 认证机制，为空时由驱动协商
+	AuthProperties map[string]string // 认证机制属性，如 SERVICE_NAME、ENVIRONMENT
+	TokenProvider  TokenProvider     // MONGODB-OIDC 令牌提供者，仅支持代码注入
+	TLSEnabled     bool              // 是否启用 TLS
+	TLSCAFile      string            // CA 证书文件，为空时使用系统根证书
+	TLSCertFile    string            // 客户端证书文件（可包含私钥）
+	TLSKeyFile     string            // 客户端私钥文件，为空时从 TLSCertFile 读取
+	TLSKeyPassword string            // 加密私钥的密码
+	TLSServerName  string            // 覆盖用于 SNI 与证书校验的服务端名称
+	TLSInsecure    bool              // 跳过服务端证书校验，仅用于开发环境
 	MaxPoolSize    uint64
 	MinPoolSize    uint64
 	ConnectTimeout time.Duration
@@ -0,0 +1,284 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-anyway/framework-log"
+	"github.com/youmark/pkcs8"
+	"go.uber.org/zap"
+)
+
+// newTLSConfig 根据选项构建 tls.Config
+// 证书从磁盘按需重新加载，证书轮换后新建立的连接会自动使用新证书，无需重建 MongoDBClient
+func newTLSConfig(opts *Options) (*tls.Config, error) {
+	reloader := &tlsReloader{
+		caFile:      opts.TLSCAFile,
+		certFile:    opts.TLSCertFile,
+		keyFile:     opts.TLSKeyFile,
+		keyPassword: opts.TLSKeyPassword,
+	}
+	if err := reloader.load(); err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: opts.TLSServerName,
+	}
+	if opts.TLSCertFile != "" {
+		cfg.GetClientCertificate = reloader.clientCertificate
+	}
+
+	switch {
+	case opts.TLSInsecure:
+		// 仅用于开发环境：不校验服务端证书
+		cfg.InsecureSkipVerify = true
+	case opts.TLSCAFile != "":
+		// 使用自定义 CA 时自行完成证书链校验，以便 CA 文件轮换后无需重建 tls.Config
+		// 注意：此时驱动不会再执行 OCSP 校验
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = reloader.verifyConnection
+	}
+	return cfg, nil
+}
+
+// fileStamp 记录文件的修改时间和大小，用于判断文件是否发生变化
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// statFile 获取文件当前的 fileStamp，路径为空时返回零值
+func statFile(path string) (fileStamp, error) {
+	if path == "" {
+		return fileStamp{}, nil
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: fi.ModTime(), size: fi.Size()}, nil
+}
+
+// tlsReloader 缓存 CA 与客户端证书，并在文件变化时重新加载
+type tlsReloader struct {
+	caFile      string
+	certFile    string
+	keyFile     string
+	keyPassword string
+
+	mu     sync.Mutex
+	roots  *x509.CertPool
+	cert   *tls.Certificate
+	stamps [3]fileStamp // ca, cert, key
+}
+
+// load 首次加载证书，任何错误都直接返回
+func (r *tlsReloader) load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err := r.reloadLocked()
+	return err
+}
+
+// reloadLocked 检查文件是否变化并在变化时重新加载，返回是否发生了重新加载
+func (r *tlsReloader) reloadLocked() (bool, error) {
+	var stamps [3]fileStamp
+	for i, path := range []string{r.caFile, r.certFile, r.keyFile} {
+		st, err := statFile(path)
+		if err != nil {
+			return false, fmt.Errorf("failed to stat tls file: %w", err)
+		}
+		stamps[i] = st
+	}
+	if stamps == r.stamps && (r.caFile == "" || r.roots != nil) && (r.certFile == "" || r.cert != nil) {
+		return false, nil
+	}
+
+	var roots *x509.CertPool
+	if r.caFile != "" {
+		data, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read tls ca file: %w", err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(data) {
+			return false, fmt.Errorf("failed to parse tls ca file %s: no certificates found", r.caFile)
+		}
+	}
+
+	var cert *tls.Certificate
+	if r.certFile != "" {
+		c, err := loadKeyPair(r.certFile, r.keyFile, r.keyPassword)
+		if err != nil {
+			return false, err
+		}
+		cert = &c
+	}
+
+	r.roots = roots
+	r.cert = cert
+	r.stamps = stamps
+	return true, nil
+}
+
+// refresh 在握手时调用，重新加载失败时保留旧证书并记录日志
+func (r *tlsReloader) refresh() {
+	reloaded, err := r.reloadLocked()
+	if err != nil {
+		log.FromContext(context.Background()).Warn(
+			"MongoDB tls certificate reload failed, keep using previous certificate",
+			zap.Error(err),
+		)
+		return
+	}
+	if reloaded {
+		log.FromContext(context.Background()).Info(
+			"MongoDB tls certificate reloaded",
+			zap.String("ca_file", r.caFile),
+			zap.String("cert_file", r.certFile),
+		)
+	}
+}
+
+// clientCertificate 实现 tls.Config.GetClientCertificate
+func (r *tlsReloader) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refresh()
+	return r.cert, nil
+}
+
+// verifyConnection 实现 tls.Config.VerifyConnection，使用最新的 CA 校验服务端证书链
+func (r *tlsReloader) verifyConnection(cs tls.ConnectionState) error {
+	r.mu.Lock()
+	r.refresh()
+	roots := r.roots
+	r.mu.Unlock()
+
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("mongodb tls: server did not present a certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(c)
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       cs.ServerName,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// loadKeyPair 加载客户端证书与私钥，keyFile 为空时从 certFile 中读取私钥（证书与私钥合并的 PEM 文件）
+// 支持使用 keyPassword 解密传统 PEM 加密或 PKCS#8 加密的私钥
+func loadKeyPair(certFile, keyFile, keyPassword string) (tls.Certificate, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to read tls cert file: %w", err)
+	}
+	keyPEM := certPEM
+	if keyFile != "" {
+		keyPEM, err = os.ReadFile(keyFile)
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("failed to read tls key file: %w", err)
+		}
+	}
+
+	var certBlocks, keyBlocks [][]byte
+	for rest := certPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			certBlocks = append(certBlocks, pem.EncodeToMemory(block))
+		}
+	}
+	for rest := keyPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			continue
+		}
+		decrypted, err := decryptKeyBlock(block, keyPassword)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		keyBlocks = append(keyBlocks, pem.EncodeToMemory(decrypted))
+	}
+	if len(certBlocks) == 0 {
+		return tls.Certificate{}, fmt.Errorf("failed to find CERTIFICATE in %s", certFile)
+	}
+	if len(keyBlocks) == 0 {
+		return tls.Certificate{}, fmt.Errorf("failed to find PRIVATE KEY for %s", certFile)
+	}
+
+	cert, err := tls.X509KeyPair(bytes.Join(certBlocks, nil), bytes.Join(keyBlocks, nil))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to load tls key pair: %w", err)
+	}
+	return cert, nil
+}
+
+// decryptKeyBlock 解密加密的私钥块，未加密时原样返回
+func decryptKeyBlock(block *pem.Block, password string) (*pem.Block, error) {
+	//nolint:staticcheck // 传统 PEM 加密已不推荐使用，但仍需兼容已有私钥
+	legacy := x509.IsEncryptedPEMBlock(block)
+	if !legacy && block.Type != "ENCRYPTED PRIVATE KEY" {
+		return block, nil
+	}
+	if password == "" {
+		return nil, fmt.Errorf("tls private key is encrypted but no tls_key_password provided")
+	}
+
+	if legacy {
+		//nolint:staticcheck // 同上
+		der, err := x509.DecryptPEMBlock(block, []byte(password))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt tls private key: %w", err)
+		}
+		return &pem.Block{Type: block.Type, Bytes: der}, nil
+	}
+
+	key, err := pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(password))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt tls private key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tls private key: %w", err)
+	}
+	return &pem.Block{Type: "PRIVATE KEY", Bytes: der}, nil
+}
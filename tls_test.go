@@ -0,0 +1,165 @@
+package mongodb
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCert 生成自签名证书和私钥并写入 dir，返回证书与私钥路径
+func writeTestCert(t *testing.T, dir, cn string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		DNSNames:              []string{cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestNewTLSConfig_ReloadsRotatedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir, "client-a")
+
+	cfg, err := newTLSConfig(&Options{
+		TLSEnabled:  true,
+		TLSCAFile:   certFile,
+		TLSCertFile: certFile,
+		TLSKeyFile:  keyFile,
+	})
+	if err != nil {
+		t.Fatalf("newTLSConfig() error = %v", err)
+	}
+	if !cfg.InsecureSkipVerify || cfg.VerifyConnection == nil {
+		t.Error("custom CA should be verified by VerifyConnection")
+	}
+
+	first, err := cfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+	if err != nil {
+		t.Fatalf("GetClientCertificate() error = %v", err)
+	}
+
+	// 模拟证书轮换：重新生成并确保修改时间变化
+	writeTestCert(t, dir, "client-b")
+	later := time.Now().Add(time.Minute)
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	second, err := cfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+	if err != nil {
+		t.Fatalf("GetClientCertificate() error = %v", err)
+	}
+	leaf, err := x509.ParseCertificate(second.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second || leaf.Subject.CommonName != "client-b" {
+		t.Errorf("certificate was not reloaded, subject = %v", leaf.Subject.CommonName)
+	}
+
+	if err := cfg.VerifyConnection(tls.ConnectionState{ServerName: "client-b", PeerCertificates: []*x509.Certificate{leaf}}); err != nil {
+		t.Errorf("VerifyConnection() with rotated CA error = %v", err)
+	}
+}
+
+func TestNewTLSConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, _ := writeTestCert(t, dir, "client")
+
+	if _, err := newTLSConfig(&Options{TLSEnabled: true, TLSCAFile: filepath.Join(dir, "missing.pem")}); err == nil {
+		t.Error("newTLSConfig() should fail for missing ca file")
+	}
+	// 证书文件中不含私钥
+	if _, err := newTLSConfig(&Options{TLSEnabled: true, TLSCertFile: certFile}); err == nil {
+		t.Error("newTLSConfig() should fail when private key is missing")
+	}
+}
+
+func TestConfig_Validate_TLS(t *testing.T) {
+	base := func() Config {
+		return Config{
+			Enabled:     true,
+			Host:        "localhost",
+			Port:        27017,
+			Database:    "testdb",
+			MaxPoolSize: 100,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr bool
+	}{
+		{"ca without tls", func(c *Config) { c.TLSCAFile = "ca.pem" }, true},
+		{"key without cert", func(c *Config) {
+			c.TLSEnabled = true
+			c.TLSKeyFile = "key.pem"
+		}, true},
+		{"x509 without cert", func(c *Config) {
+			c.TLSEnabled = true
+			c.AuthMechanism = AuthMechanismX509
+		}, true},
+		{"x509", func(c *Config) {
+			c.TLSEnabled = true
+			c.TLSCertFile = "client.pem"
+			c.AuthMechanism = AuthMechanismX509
+		}, false},
+		{"insecure with ca", func(c *Config) {
+			c.TLSEnabled = true
+			c.TLSInsecure = true
+			c.TLSCAFile = "ca.pem"
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := base()
+			tt.modify(&c)
+			err := c.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
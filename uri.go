@@ -0,0 +1,198 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package mongodb
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+)
+
+// parseURI 解析原始连接串并检查与结构化字段的冲突
+//
+// 合并规则：
+//   - hosts 与 URI 不能同时设置；replica_set、username、password 与 URI 中的值不一致时报错
+//   - direct_connection、load_balanced 为 true 时覆盖 URI，URI 显式设为 false 时报错
+//   - auth_mechanism 与 URI 中的 authMechanism 不一致时报错，认证属性按键合并（URI 优先）
+//   - URI 中设置了 authSource 时以 URI 为准，否则使用 auth_source
+//   - 连接池、超时等调优字段非零时覆盖 URI 中的同名参数
+//
+// mongodb+srv:// 只做语法校验，不进行 DNS 解析；SRV 解析在建立连接时由驱动完成。
+func (o *Options) parseURI() (*connstring.ConnString, error) {
+	cs, err := parseConnString(o.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(o.Hosts) > 0 {
+		return nil, fmt.Errorf("mongodb hosts cannot be used together with uri (uri already specifies hosts %v)", cs.RawHosts)
+	}
+	if o.ReplicaSet != "" && cs.ReplicaSet != "" && o.ReplicaSet != cs.ReplicaSet {
+		return nil, fmt.Errorf("mongodb replica_set %q conflicts with uri replicaSet %q", o.ReplicaSet, cs.ReplicaSet)
+	}
+	if o.Username != "" && cs.UsernameSet && o.Username != cs.Username {
+		return nil, fmt.Errorf("mongodb username %q conflicts with uri username %q", o.Username, cs.Username)
+	}
+	if o.Password != "" && cs.PasswordSet && o.Password != cs.Password {
+		return nil, fmt.Errorf("mongodb password conflicts with uri password")
+	}
+	if o.AuthMechanism != "" && cs.AuthMechanism != "" && !strings.EqualFold(o.AuthMechanism, cs.AuthMechanism) {
+		return nil, fmt.Errorf("mongodb auth_mechanism %q conflicts with uri authMechanism %q", o.AuthMechanism, cs.AuthMechanism)
+	}
+	if o.Direct {
+		if cs.DirectConnectionSet && !cs.DirectConnection {
+			return nil, fmt.Errorf("mongodb direct_connection conflicts with uri directConnection=false")
+		}
+		if cs.Scheme == connstring.SchemeMongoDBSRV {
+			return nil, fmt.Errorf("mongodb direct_connection cannot be used with a %s uri", connstring.SchemeMongoDBSRV)
+		}
+		if len(cs.RawHosts) > 1 {
+			return nil, fmt.Errorf("mongodb direct_connection requires exactly one host, uri has %d", len(cs.RawHosts))
+		}
+	}
+	if o.LoadBalanced && cs.LoadBalancedSet && !cs.LoadBalanced {
+		return nil, fmt.Errorf("mongodb load_balanced conflicts with uri loadBalanced=false")
+	}
+	return cs, nil
+}
+
+// uriClientOptions 应用原始连接串，再用非零的结构化字段覆盖，返回客户端选项和数据库名称
+func uriClientOptions(opts *Options) (*options.ClientOptions, string, error) {
+	cs, err := opts.parseURI()
+	if err != nil {
+		return nil, "", err
+	}
+	clientOptions := options.Client().ApplyURI(opts.URI)
+	if opts.ReplicaSet != "" {
+		clientOptions.SetReplicaSet(opts.ReplicaSet)
+	}
+	if opts.Direct {
+		clientOptions.SetDirect(true)
+	}
+	if opts.LoadBalanced {
+		clientOptions.SetLoadBalanced(true)
+	}
+	if err := mergeURICredential(clientOptions, cs, opts); err != nil {
+		return nil, "", err
+	}
+	if opts.MaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(opts.MaxPoolSize)
+	}
+	if opts.MinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(opts.MinPoolSize)
+	}
+	if opts.ConnectTimeout > 0 {
+		clientOptions.SetConnectTimeout(opts.ConnectTimeout)
+	} else if clientOptions.ConnectTimeout == nil {
+		clientOptions.SetConnectTimeout(defaultConnectTimeout)
+	}
+	if opts.SocketTimeout > 0 {
+		clientOptions.SetSocketTimeout(opts.SocketTimeout)
+	}
+
+	dbName := opts.Database
+	if dbName == "" {
+		dbName = cs.Database
+	}
+	return clientOptions, dbName, nil
+}
+
+// mergeURICredential 合并连接串与结构化字段中的认证信息，结构化字段只补充连接串未设置的部分
+func mergeURICredential(clientOptions *options.ClientOptions, cs *connstring.ConnString, opts *Options) error {
+	merged := *opts
+	if cs.UsernameSet {
+		merged.Username = cs.Username
+	}
+	if cs.PasswordSet {
+		merged.Password = cs.Password
+	}
+	if cs.AuthMechanism != "" {
+		merged.AuthMechanism = strings.ToUpper(cs.AuthMechanism)
+	}
+	if len(cs.AuthMechanismProperties) > 0 {
+		props := make(map[string]string, len(opts.AuthProperties)+len(cs.AuthMechanismProperties))
+		for k, v := range opts.AuthProperties {
+			props[k] = v
+		}
+		for k, v := range cs.AuthMechanismProperties {
+			props[k] = v
+		}
+		merged.AuthProperties = props
+	}
+
+	cred, err := buildCredential(&merged)
+	if err != nil {
+		return err
+	}
+	if cred == nil {
+		return nil
+	}
+	if cs.AuthSourceSet && cred.AuthSource != externalAuthSource {
+		cred.AuthSource = cs.AuthSource
+	}
+	clientOptions.SetAuth(*cred)
+	return nil
+}
+
+// parseConnString 解析连接串但不触发 SRV/TXT DNS 查询
+func parseConnString(uri string) (*connstring.ConnString, error) {
+	raw := uri
+	srv := strings.HasPrefix(uri, connstring.SchemeMongoDBSRV+"://")
+	if srv {
+		// 驱动的解析器会对 mongodb+srv 直接发起 DNS 查询，这里改写为普通 scheme 做离线校验
+		raw = connstring.SchemeMongoDB + "://" + stripSRVOptions(uri[len(connstring.SchemeMongoDBSRV)+3:])
+	}
+
+	cs, err := connstring.ParseAndValidate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("mongodb uri is invalid: %w", err)
+	}
+	if srv {
+		if len(cs.RawHosts) != 1 {
+			return nil, fmt.Errorf("mongodb uri is invalid: %s uri must have exactly one host, got %d", connstring.SchemeMongoDBSRV, len(cs.RawHosts))
+		}
+		if strings.Contains(cs.RawHosts[0], ":") {
+			return nil, fmt.Errorf("mongodb uri is invalid: %s uri cannot specify a port", connstring.SchemeMongoDBSRV)
+		}
+		cs.Scheme = connstring.SchemeMongoDBSRV
+		cs.Original = uri
+	}
+	return cs, nil
+}
+
+// stripSRVOptions 去掉仅对 mongodb+srv 合法的查询参数
+func stripSRVOptions(rest string) string {
+	idx := strings.Index(rest, "?")
+	if idx == -1 {
+		return rest
+	}
+	kept := make([]string, 0)
+	for _, pair := range strings.FieldsFunc(rest[idx+1:], func(r rune) bool { return r == '&' || r == ';' }) {
+		key, _, _ := strings.Cut(pair, "=")
+		switch strings.ToLower(key) {
+		case "srvmaxhosts", "srvservicename":
+			continue
+		}
+		kept = append(kept, pair)
+	}
+	if len(kept) == 0 {
+		return rest[:idx]
+	}
+	return rest[:idx+1] + strings.Join(kept, "&")
+}